@@ -0,0 +1,228 @@
+package creditdb
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+)
+
+// BatchResult carries the outcome of a single key within a batch
+// operation. Err is non-nil when that entry failed; a partial failure
+// does not abort the rest of the batch.
+type BatchResult struct {
+	Key   string `json:"key"`
+	Value string `json:"value,omitempty"`
+	Err   *Error `json:"error,omitempty"`
+}
+
+type batchLinesRequest struct {
+	Page  uint   `json:"page"`
+	Items []Line `json:"items"`
+}
+
+type batchKeysRequest struct {
+	Page  uint     `json:"page"`
+	Items []string `json:"items"`
+}
+
+type batchResponse struct {
+	Status  string        `json:"status"`
+	Results []BatchResult `json:"results"`
+}
+
+func asError(err error) *Error {
+	if e, ok := err.(*Error); ok {
+		return e
+	}
+	return ErrInternalError
+}
+
+// doBatch posts bodyJSON to the batch endpoint and decodes its results.
+// When the server doesn't recognize the batch route (404), it reports
+// fellBack so the caller can loop the equivalent single-key endpoint.
+func (c *CreditDB) doBatch(ctx context.Context, endpoint string, bodyJSON []byte) (results []BatchResult, fellBack bool, err error) {
+	url := fmt.Sprintf("%s/%s", c.config.host, endpoint)
+	var response batchResponse
+	err = c.doWithRetry(ctx, func() (*http.Request, error) {
+		req, err := http.NewRequestWithContext(ctx, "POST", url, bytes.NewBuffer(bodyJSON))
+		if err != nil {
+			return nil, err
+		}
+		req.Header.Set("Content-Type", "application/json")
+		return req, nil
+	}, http.StatusOK, func(resp *http.Response) error {
+		return json.NewDecoder(resp.Body).Decode(&response)
+	})
+	if errors.Is(err, ErrNotFound) {
+		return nil, true, nil
+	}
+	if err != nil {
+		return nil, false, err
+	}
+	if response.Status != "OK" {
+		return nil, false, ErrBadRequest
+	}
+	return response.Results, false, nil
+}
+
+// MSet writes every line in lines in a single round trip, returning a
+// BatchResult per entry so partial failures are reported without
+// aborting the rest of the batch. When the server doesn't support the
+// /mset route, MSet transparently falls back to one SetLine call per
+// entry.
+func (c *CreditDB) MSet(ctx context.Context, lines []Line) ([]BatchResult, error) {
+	bodyJSON, err := json.Marshal(batchLinesRequest{Page: c.config.currentPage, Items: lines})
+	if err != nil {
+		return nil, ErrInternalError
+	}
+
+	results, fellBack, err := c.doBatch(ctx, "mset", bodyJSON)
+	if err != nil {
+		return nil, err
+	}
+	if !fellBack {
+		return results, nil
+	}
+
+	results = make([]BatchResult, len(lines))
+	for i, line := range lines {
+		if err := c.SetLine(ctx, line.Key, line.Value); err != nil {
+			results[i] = BatchResult{Key: line.Key, Err: asError(err)}
+			continue
+		}
+		results[i] = BatchResult{Key: line.Key, Value: line.Value}
+	}
+	return results, nil
+}
+
+// MGet reads every key in keys in a single round trip. When the server
+// doesn't support the /mget route, MGet transparently falls back to one
+// GetLine call per key.
+func (c *CreditDB) MGet(ctx context.Context, keys []string) ([]BatchResult, error) {
+	bodyJSON, err := json.Marshal(batchKeysRequest{Page: c.config.currentPage, Items: keys})
+	if err != nil {
+		return nil, ErrInternalError
+	}
+
+	results, fellBack, err := c.doBatch(ctx, "mget", bodyJSON)
+	if err != nil {
+		return nil, err
+	}
+	if !fellBack {
+		return results, nil
+	}
+
+	results = make([]BatchResult, len(keys))
+	for i, key := range keys {
+		line, err := c.GetLine(ctx, key)
+		if err != nil {
+			results[i] = BatchResult{Key: key, Err: asError(err)}
+			continue
+		}
+		results[i] = BatchResult{Key: key, Value: line.Value}
+	}
+	return results, nil
+}
+
+// MDelete deletes every key in keys in a single round trip. When the
+// server doesn't support the /mdelete route, MDelete transparently
+// falls back to one DeleteLine call per key.
+func (c *CreditDB) MDelete(ctx context.Context, keys []string) ([]BatchResult, error) {
+	bodyJSON, err := json.Marshal(batchKeysRequest{Page: c.config.currentPage, Items: keys})
+	if err != nil {
+		return nil, ErrInternalError
+	}
+
+	results, fellBack, err := c.doBatch(ctx, "mdelete", bodyJSON)
+	if err != nil {
+		return nil, err
+	}
+	if !fellBack {
+		return results, nil
+	}
+
+	results = make([]BatchResult, len(keys))
+	for i, key := range keys {
+		if err := c.DeleteLine(ctx, key); err != nil {
+			results[i] = BatchResult{Key: key, Err: asError(err)}
+			continue
+		}
+		results[i] = BatchResult{Key: key}
+	}
+	return results, nil
+}
+
+// Pipeliner accumulates queued commands and flushes them on Exec,
+// similar in spirit to the pipelining pattern offered by common Go KV
+// clients: at most one round trip per command kind rather than one per
+// command, except where the server lacks that kind's batch route and
+// the call falls back to one single-key request per command.
+type Pipeliner struct {
+	client *CreditDB
+	sets   []Line
+	gets   []string
+	dels   []string
+}
+
+// Pipeline returns a Pipeliner bound to c.
+func (c *CreditDB) Pipeline() *Pipeliner {
+	return &Pipeliner{client: c}
+}
+
+// Set queues a SetLine command.
+func (p *Pipeliner) Set(key, value string) *Pipeliner {
+	p.sets = append(p.sets, Line{Key: key, Value: value})
+	return p
+}
+
+// Get queues a GetLine command.
+func (p *Pipeliner) Get(key string) *Pipeliner {
+	p.gets = append(p.gets, key)
+	return p
+}
+
+// Delete queues a DeleteLine command.
+func (p *Pipeliner) Delete(key string) *Pipeliner {
+	p.dels = append(p.dels, key)
+	return p
+}
+
+// PipelineResults groups the per-command-kind results of an Exec call.
+type PipelineResults struct {
+	Set    []BatchResult
+	Get    []BatchResult
+	Delete []BatchResult
+}
+
+// Exec flushes every queued command, issuing at most one MSet, MGet,
+// and MDelete call, then clears the queue. On the first error it
+// returns without clearing the queue.
+func (p *Pipeliner) Exec(ctx context.Context) (*PipelineResults, error) {
+	results := &PipelineResults{}
+	if len(p.sets) > 0 {
+		r, err := p.client.MSet(ctx, p.sets)
+		if err != nil {
+			return nil, err
+		}
+		results.Set = r
+	}
+	if len(p.gets) > 0 {
+		r, err := p.client.MGet(ctx, p.gets)
+		if err != nil {
+			return nil, err
+		}
+		results.Get = r
+	}
+	if len(p.dels) > 0 {
+		r, err := p.client.MDelete(ctx, p.dels)
+		if err != nil {
+			return nil, err
+		}
+		results.Delete = r
+	}
+	p.sets, p.gets, p.dels = nil, nil, nil
+	return results, nil
+}
@@ -0,0 +1,111 @@
+package creditdb
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+func TestMSetUsesBatchEndpointWhenAvailable(t *testing.T) {
+	var gotPath string
+	var gotItems []Line
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		gotPath = r.URL.Path
+		var body batchLinesRequest
+		json.NewDecoder(r.Body).Decode(&body)
+		gotItems = body.Items
+		w.Header().Set("Content-Type", "application/json")
+		json.NewEncoder(w).Encode(batchResponse{
+			Status: "OK",
+			Results: []BatchResult{
+				{Key: "a", Value: "1"},
+				{Key: "b", Value: "2"},
+			},
+		})
+	}))
+	defer srv.Close()
+
+	c := testClient(srv.URL)
+	results, err := c.MSet(context.Background(), []Line{{Key: "a", Value: "1"}, {Key: "b", Value: "2"}})
+	if err != nil {
+		t.Fatalf("MSet returned error: %v", err)
+	}
+	if gotPath != "/mset" {
+		t.Fatalf("expected POST to /mset, got %q", gotPath)
+	}
+	if len(gotItems) != 2 {
+		t.Fatalf("expected both lines in a single round trip, got %d items", len(gotItems))
+	}
+	if len(results) != 2 || results[0].Value != "1" || results[1].Value != "2" {
+		t.Fatalf("unexpected results: %+v", results)
+	}
+}
+
+func TestMSetFallsBackToSetLineOn404(t *testing.T) {
+	var setCalls int
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/mset":
+			w.WriteHeader(http.StatusNotFound)
+		case "/set":
+			setCalls++
+			w.WriteHeader(http.StatusOK)
+		default:
+			t.Fatalf("unexpected path %q", r.URL.Path)
+		}
+	}))
+	defer srv.Close()
+
+	c := testClient(srv.URL)
+	lines := []Line{{Key: "a", Value: "1"}, {Key: "b", Value: "2"}}
+	results, err := c.MSet(context.Background(), lines)
+	if err != nil {
+		t.Fatalf("MSet returned error: %v", err)
+	}
+	if setCalls != len(lines) {
+		t.Fatalf("expected one SetLine fallback call per line, got %d calls for %d lines", setCalls, len(lines))
+	}
+	if len(results) != len(lines) {
+		t.Fatalf("expected one BatchResult per line, got %d", len(results))
+	}
+	for i, line := range lines {
+		if results[i].Key != line.Key || results[i].Value != line.Value || results[i].Err != nil {
+			t.Fatalf("unexpected fallback result at %d: %+v", i, results[i])
+		}
+	}
+}
+
+func TestPipelinerExecFlushesQueuedCommands(t *testing.T) {
+	var msetCalls, mgetCalls, mdeleteCalls int
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		switch r.URL.Path {
+		case "/mset":
+			msetCalls++
+			json.NewEncoder(w).Encode(batchResponse{Status: "OK", Results: []BatchResult{{Key: "a", Value: "1"}}})
+		case "/mget":
+			mgetCalls++
+			json.NewEncoder(w).Encode(batchResponse{Status: "OK", Results: []BatchResult{{Key: "b", Value: "2"}}})
+		case "/mdelete":
+			mdeleteCalls++
+			json.NewEncoder(w).Encode(batchResponse{Status: "OK", Results: []BatchResult{{Key: "c"}}})
+		default:
+			t.Fatalf("unexpected path %q", r.URL.Path)
+		}
+	}))
+	defer srv.Close()
+
+	c := testClient(srv.URL)
+	results, err := c.Pipeline().Set("a", "1").Get("b").Delete("c").Exec(context.Background())
+	if err != nil {
+		t.Fatalf("Exec returned error: %v", err)
+	}
+	if msetCalls != 1 || mgetCalls != 1 || mdeleteCalls != 1 {
+		t.Fatalf("expected exactly one round trip per command kind, got mset=%d mget=%d mdelete=%d", msetCalls, mgetCalls, mdeleteCalls)
+	}
+	if len(results.Set) != 1 || len(results.Get) != 1 || len(results.Delete) != 1 {
+		t.Fatalf("expected one result per queued command kind, got %+v", results)
+	}
+}
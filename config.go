@@ -0,0 +1,162 @@
+package creditdb
+
+import (
+	"context"
+	"crypto/tls"
+	"net/http"
+	"time"
+
+	"github.com/caarlos0/env/v9"
+	"github.com/cenkalti/backoff/v4"
+)
+
+// Config holds the client's env-driven configuration, letting 12-factor
+// deployments avoid the WithHost(...) chain and opt into bearer auth or
+// TLS the server can enforce. Load it with LoadConfig.
+type Config struct {
+	Host       string        `env:"CREDITDB_HOST" envDefault:"http://localhost:5622"`
+	Page       uint          `env:"CREDITDB_PAGE" envDefault:"0"`
+	Timeout    time.Duration `env:"CREDITDB_TIMEOUT" envDefault:"5s"`
+	MaxRetries int           `env:"CREDITDB_MAX_RETRIES" envDefault:"3"`
+	AuthToken  string        `env:"CREDITDB_AUTH_TOKEN"`
+	UserAgent  string        `env:"CREDITDB_USER_AGENT" envDefault:"go-creditdb"`
+	TLSConfig  *tls.Config   `env:"-"`
+}
+
+func defaultConfig() Config {
+	return Config{
+		Host:       defaultHost,
+		Page:       defaultPage,
+		Timeout:    5 * time.Second,
+		MaxRetries: defaultRetryPolicy.MaxAttempts,
+		UserAgent:  "go-creditdb",
+	}
+}
+
+// LoadConfig reads a Config from the process environment, applying each
+// field's envDefault for a variable that isn't set.
+func LoadConfig() (Config, error) {
+	cfg := defaultConfig()
+	if err := env.Parse(&cfg); err != nil {
+		return Config{}, NewError("failed to load config from environment", CategoryBadRequest).WithCause(err)
+	}
+	return cfg, nil
+}
+
+// Logger is the minimal logging interface WithLogger accepts; the
+// standard library *log.Logger satisfies it.
+type Logger interface {
+	Println(v ...interface{})
+}
+
+// Middleware wraps an http.RoundTripper, letting callers inject tracing,
+// metrics, or auth headers around every request the client issues.
+type Middleware func(http.RoundTripper) http.RoundTripper
+
+type clientOptions struct {
+	bearerToken string
+	tlsConfig   *tls.Config
+	logger      Logger
+	middleware  []Middleware
+}
+
+// Option configures a CreditDB constructed via NewClientWithConfig.
+type Option func(*clientOptions)
+
+// WithBearerToken attaches an `Authorization: Bearer <token>` header to
+// every request, overriding Config.AuthToken if both are set.
+func WithBearerToken(token string) Option {
+	return func(o *clientOptions) { o.bearerToken = token }
+}
+
+// WithTLS overrides Config.TLSConfig for the client's transport.
+func WithTLS(tlsConfig *tls.Config) Option {
+	return func(o *clientOptions) { o.tlsConfig = tlsConfig }
+}
+
+// WithLogger logs the startup health-check failure through logger.
+// Without it, NewClientWithConfig logs nothing and only returns the
+// error.
+func WithLogger(logger Logger) Option {
+	return func(o *clientOptions) { o.logger = logger }
+}
+
+// WithMiddleware wraps the client's transport with mw. Middleware is
+// applied in the order given to NewClientWithConfig, so the last one
+// added wraps outermost and runs first on each request.
+func WithMiddleware(mw Middleware) Option {
+	return func(o *clientOptions) { o.middleware = append(o.middleware, mw) }
+}
+
+type headerTransport struct {
+	key, value string
+	next       http.RoundTripper
+}
+
+func (t headerTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	req = req.Clone(req.Context())
+	req.Header.Set(t.key, t.value)
+	return t.next.RoundTrip(req)
+}
+
+// NewClientWithConfig builds a CreditDB from cfg and opts, probing
+// Health with the same exponential backoff NewClient has always used
+// before handing back a client an operator can rely on.
+func NewClientWithConfig(cfg Config, opts ...Option) (*CreditDB, error) {
+	o := &clientOptions{}
+	for _, opt := range opts {
+		opt(o)
+	}
+
+	tlsConfig := cfg.TLSConfig
+	if o.tlsConfig != nil {
+		tlsConfig = o.tlsConfig
+	}
+
+	var rt http.RoundTripper = &http.Transport{
+		ResponseHeaderTimeout: cfg.Timeout,
+		TLSClientConfig:       tlsConfig,
+	}
+
+	token := cfg.AuthToken
+	if o.bearerToken != "" {
+		token = o.bearerToken
+	}
+	if token != "" {
+		rt = headerTransport{key: "Authorization", value: "Bearer " + token, next: rt}
+	}
+	if cfg.UserAgent != "" {
+		rt = headerTransport{key: "User-Agent", value: cfg.UserAgent, next: rt}
+	}
+	for _, mw := range o.middleware {
+		rt = mw(rt)
+	}
+
+	client := &CreditDB{
+		config: config{host: cfg.Host, currentPage: cfg.Page},
+		client: &http.Client{Transport: rt},
+		retry: RetryPolicy{
+			MaxAttempts:     cfg.MaxRetries,
+			InitialInterval: defaultRetryPolicy.InitialInterval,
+			MaxInterval:     defaultRetryPolicy.MaxInterval,
+			MaxElapsedTime:  defaultRetryPolicy.MaxElapsedTime,
+		},
+		deadlines: newDeadlineManager(),
+	}
+
+	b := backoff.NewExponentialBackOff()
+	b.InitialInterval = 100 * time.Millisecond
+	b.MaxElapsedTime = 5 * time.Second
+	b.MaxInterval = 30 * time.Second
+
+	operation := func() error {
+		return client.Health(context.Background())
+	}
+	if err := backoff.Retry(operation, b); err != nil {
+		if o.logger != nil {
+			o.logger.Println("health check failed with error: ", err)
+		}
+		return nil, err
+	}
+	return client, nil
+}
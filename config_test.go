@@ -0,0 +1,86 @@
+package creditdb
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+func TestLoadConfigDefaults(t *testing.T) {
+	cfg, err := LoadConfig()
+	if err != nil {
+		t.Fatalf("LoadConfig returned error: %v", err)
+	}
+	if cfg.Host != defaultHost {
+		t.Fatalf("expected default host %q, got %q", defaultHost, cfg.Host)
+	}
+	if cfg.UserAgent != "go-creditdb" {
+		t.Fatalf("expected default user agent go-creditdb, got %q", cfg.UserAgent)
+	}
+}
+
+func TestNewClientWithConfigAppliesBearerTokenAndUserAgent(t *testing.T) {
+	var gotAuth, gotUA string
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		gotAuth = r.Header.Get("Authorization")
+		gotUA = r.Header.Get("User-Agent")
+		w.WriteHeader(http.StatusOK)
+	}))
+	defer srv.Close()
+
+	cfg := defaultConfig()
+	cfg.Host = srv.URL
+	client, err := NewClientWithConfig(cfg, WithBearerToken("s3cr3t"))
+	if err != nil {
+		t.Fatalf("NewClientWithConfig returned error: %v", err)
+	}
+
+	if err := client.Health(context.Background()); err != nil {
+		t.Fatalf("Health returned error: %v", err)
+	}
+	if gotAuth != "Bearer s3cr3t" {
+		t.Fatalf("expected Authorization header to be set via WithBearerToken, got %q", gotAuth)
+	}
+	if gotUA != "go-creditdb" {
+		t.Fatalf("expected User-Agent header from Config.UserAgent, got %q", gotUA)
+	}
+}
+
+func TestNewClientWithConfigMiddlewareOrderingLastWrapsOutermost(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusOK)
+	}))
+	defer srv.Close()
+
+	var order []string
+	mw := func(name string) Middleware {
+		return func(next http.RoundTripper) http.RoundTripper {
+			return roundTripFunc(func(req *http.Request) (*http.Response, error) {
+				order = append(order, name)
+				return next.RoundTrip(req)
+			})
+		}
+	}
+
+	cfg := defaultConfig()
+	cfg.Host = srv.URL
+	client, err := NewClientWithConfig(cfg, WithMiddleware(mw("A")), WithMiddleware(mw("B")))
+	if err != nil {
+		t.Fatalf("NewClientWithConfig returned error: %v", err)
+	}
+	order = nil // NewClientWithConfig's own startup health probe already ran the chain once
+
+	if err := client.Health(context.Background()); err != nil {
+		t.Fatalf("Health returned error: %v", err)
+	}
+	if len(order) != 2 || order[0] != "B" || order[1] != "A" {
+		t.Fatalf("expected the last-added middleware (B) to run first, got %v", order)
+	}
+}
+
+type roundTripFunc func(*http.Request) (*http.Response, error)
+
+func (f roundTripFunc) RoundTrip(req *http.Request) (*http.Response, error) {
+	return f(req)
+}
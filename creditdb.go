@@ -4,10 +4,9 @@ import (
 	"bytes"
 	"context"
 	"encoding/json"
+	"errors"
 	"fmt"
-	"github.com/cenkalti/backoff/v4"
 	"log"
-	"time"
 
 	"net/http"
 )
@@ -18,11 +17,15 @@ var (
 	ErrInternalError      = NewError("internal server error", CategoryInternalError)
 	ErrTimeout            = NewError("timeout", CategoryTimeout)
 	ErrServiceUnavailable = NewError("service unavailable", CategoryServiceUnavailable)
+	ErrConflict           = NewError("conflict", CategoryConflict)
+	ErrUnauthorized       = NewError("unauthorized", CategoryUnauthorized)
 )
 
 type CreditDB struct {
-	config config
-	client *http.Client
+	config    config
+	client    *http.Client
+	retry     RetryPolicy
+	deadlines *deadlineManager
 }
 
 type config struct {
@@ -44,40 +47,17 @@ type Page struct {
 const defaultHost = "http://localhost:5622"
 const defaultPage = 0
 
+// NewClient builds a CreditDB against defaultHost using the package
+// defaults. It is a thin wrapper around NewClientWithConfig for callers
+// that don't need env-driven configuration or functional options; see
+// LoadConfig and NewClientWithConfig for those.
 func NewClient() *CreditDB {
-	b := backoff.NewExponentialBackOff()
-	b.InitialInterval = 100 * time.Millisecond
-	b.MaxElapsedTime = 5 * time.Second
-	b.MaxInterval = 30 * time.Second
-
-	client := &http.Client{
-		Transport: &http.Transport{
-			ResponseHeaderTimeout: 5 * time.Second,
-		},
-	}
-
-	clonedClient := &http.Client{
-		Transport: &http.Transport{
-			ResponseHeaderTimeout: 5 * time.Second,
-		},
-	}
-	clone := &CreditDB{
-		config: config{host: defaultHost, currentPage: defaultPage},
-		client: clonedClient,
-	}
-
-	operation := func() error {
-		return clone.Health(context.Background())
-	}
-	err := backoff.Retry(operation, b)
+	client, err := NewClientWithConfig(defaultConfig())
 	if err != nil {
 		log.Println("health check failed with error: ", err)
 		return nil
 	}
-	return &CreditDB{
-		config: config{host: defaultHost, currentPage: defaultPage},
-		client: client,
-	}
+	return client
 }
 
 func (c *CreditDB) Close(ctx context.Context) error {
@@ -116,22 +96,15 @@ func (c *CreditDB) SetLine(ctx context.Context, key, value string) error {
 	if err != nil {
 		return ErrInternalError
 	}
-	req, err := http.NewRequestWithContext(ctx, "POST", setURL, bytes.NewBuffer(setJSON))
-	if err != nil {
-		return ErrInternalError
-	}
-	req.Header.Set("Content-Type", "application/json")
 
-	resp, err := c.client.Do(req)
-	if err != nil {
-		return ErrInternalError
-	}
-	defer resp.Body.Close()
-
-	if resp.StatusCode != http.StatusOK {
-		return ErrBadRequest
-	}
-	return nil
+	return c.doWithRetry(ctx, func() (*http.Request, error) {
+		req, err := http.NewRequestWithContext(ctx, "POST", setURL, bytes.NewBuffer(setJSON))
+		if err != nil {
+			return nil, err
+		}
+		req.Header.Set("Content-Type", "application/json")
+		return req, nil
+	}, http.StatusOK, nil)
 }
 
 func (c *CreditDB) GetLine(ctx context.Context, key string) (*Line, error) {
@@ -150,28 +123,20 @@ func (c *CreditDB) GetLine(ctx context.Context, key string) (*Line, error) {
 	if err != nil {
 		return nil, ErrInternalError
 	}
-	req, err := http.NewRequestWithContext(ctx, "GET", getURL, bytes.NewBuffer(getJSON))
-	if err != nil {
-		return nil, ErrInternalError
-	}
-	req.Header.Set("Content-Type", "application/json")
 
-	resp, err := c.client.Do(req)
-	if err != nil {
-		return nil, ErrInternalError
-	}
-	defer resp.Body.Close()
-	if resp.StatusCode == http.StatusNotFound {
-		return nil, ErrNotFound
-	}
-
-	if resp.StatusCode != http.StatusOK {
-		return nil, ErrBadRequest
-	}
 	var data Line
-	err = json.NewDecoder(resp.Body).Decode(&data)
+	err = c.doWithRetry(ctx, func() (*http.Request, error) {
+		req, err := http.NewRequestWithContext(ctx, "GET", getURL, bytes.NewBuffer(getJSON))
+		if err != nil {
+			return nil, err
+		}
+		req.Header.Set("Content-Type", "application/json")
+		return req, nil
+	}, http.StatusOK, func(resp *http.Response) error {
+		return json.NewDecoder(resp.Body).Decode(&data)
+	})
 	if err != nil {
-		return nil, ErrInternalError
+		return nil, err
 	}
 	return &data, nil
 }
@@ -187,20 +152,20 @@ func (c *CreditDB) GetAllLines(ctx context.Context) ([]Line, error) {
 	if err != nil {
 		return nil, ErrInternalError
 	}
-	req, err := http.NewRequestWithContext(ctx, "GET", getAllURL, bytes.NewBuffer(getAllJSON))
-	if err != nil {
-		return nil, ErrInternalError
-	}
-	req.Header.Set("Content-Type", "application/json")
-	resp, err := c.client.Do(req)
-	if err != nil {
-		return nil, ErrInternalError
-	}
-	defer resp.Body.Close()
 
 	var response Page
-	if err := json.NewDecoder(resp.Body).Decode(&response); err != nil {
-		return nil, ErrInternalError
+	err = c.doWithRetry(ctx, func() (*http.Request, error) {
+		req, err := http.NewRequestWithContext(ctx, "GET", getAllURL, bytes.NewBuffer(getAllJSON))
+		if err != nil {
+			return nil, err
+		}
+		req.Header.Set("Content-Type", "application/json")
+		return req, nil
+	}, http.StatusOK, func(resp *http.Response) error {
+		return json.NewDecoder(resp.Body).Decode(&response)
+	})
+	if err != nil {
+		return nil, err
 	}
 	if response.Status != "OK" {
 		return nil, ErrBadRequest
@@ -224,24 +189,15 @@ func (c *CreditDB) DeleteLine(ctx context.Context, key string) error {
 	if err != nil {
 		return ErrInternalError
 	}
-	req, err := http.NewRequestWithContext(ctx, "DELETE", delURL, bytes.NewBuffer(delJSON))
-	if err != nil {
-		return ErrInternalError
-	}
-	req.Header.Set("Content-Type", "application/json")
-	resp, err := c.client.Do(req)
-	if err != nil {
-		return ErrInternalError
-	}
-	defer resp.Body.Close()
-	if resp.StatusCode == http.StatusNotFound {
-		return ErrNotFound
-	}
 
-	if resp.StatusCode != http.StatusOK {
-		return ErrBadRequest
-	}
-	return nil
+	return c.doWithRetry(ctx, func() (*http.Request, error) {
+		req, err := http.NewRequestWithContext(ctx, "DELETE", delURL, bytes.NewBuffer(delJSON))
+		if err != nil {
+			return nil, err
+		}
+		req.Header.Set("Content-Type", "application/json")
+		return req, nil
+	}, http.StatusOK, nil)
 }
 
 func (c *CreditDB) Flush(ctx context.Context) error {
@@ -256,39 +212,27 @@ func (c *CreditDB) Flush(ctx context.Context) error {
 		return ErrInternalError
 	}
 
-	req, err := http.NewRequestWithContext(ctx, "DELETE", flushURL, bytes.NewBuffer(flushJSON))
-	if err != nil {
-		return ErrInternalError
-	}
-	req.Header.Set("Content-Type", "application/json")
-	resp, err := c.client.Do(req)
-	if err != nil {
-		return ErrInternalError
-	}
-	defer resp.Body.Close()
-
-	if resp.StatusCode != http.StatusOK {
-		return ErrInternalError
-	}
-	return nil
+	return c.doWithRetry(ctx, func() (*http.Request, error) {
+		req, err := http.NewRequestWithContext(ctx, "DELETE", flushURL, bytes.NewBuffer(flushJSON))
+		if err != nil {
+			return nil, err
+		}
+		req.Header.Set("Content-Type", "application/json")
+		return req, nil
+	}, http.StatusOK, nil)
 }
 
 func (c *CreditDB) Ping(ctx context.Context) (string, error) {
 	pingURL := fmt.Sprintf("%s/ping", c.config.host)
 
-	req, err := http.NewRequestWithContext(ctx, "GET", pingURL, nil)
-	if err != nil {
-		return "", ErrInternalError
-	}
-	resp, err := c.client.Do(req)
-	if err != nil {
-		return "", ErrInternalError
-	}
-	defer resp.Body.Close()
-
 	var response map[string]interface{}
-	if err := json.NewDecoder(resp.Body).Decode(&response); err != nil {
-		return "", ErrInternalError
+	err := c.doWithRetry(ctx, func() (*http.Request, error) {
+		return http.NewRequestWithContext(ctx, "GET", pingURL, nil)
+	}, http.StatusOK, func(resp *http.Response) error {
+		return json.NewDecoder(resp.Body).Decode(&response)
+	})
+	if err != nil {
+		return "", err
 	}
 	pingValue, found := response["ping"].(string)
 	if !found {
@@ -322,7 +266,7 @@ func (c *CreditDB) GetCurrentPage() uint {
 func (c *CreditDB)Exists(ctx context.Context, key string)(bool, error){
 	_, err := c.GetLine(ctx, key)
 	if err != nil {
-		if err == ErrNotFound {
+		if errors.Is(err, ErrNotFound) {
 			return false, nil
 		}
 		return false, err
@@ -0,0 +1,115 @@
+package creditdb
+
+import (
+	"context"
+	"sync"
+	"time"
+)
+
+// deadlineManager maintains a rolling read/write deadline shared by
+// every in-flight request, mirroring net.Conn's "the deadline is a
+// property of the connection, not the call" semantics rather than
+// requiring every caller to thread a context.WithDeadline through each
+// request.
+type deadlineManager struct {
+	mu sync.Mutex
+
+	readCancelCh  chan struct{}
+	writeCancelCh chan struct{}
+	readTimer     *time.Timer
+	writeTimer    *time.Timer
+}
+
+func newDeadlineManager() *deadlineManager {
+	return &deadlineManager{
+		readCancelCh:  make(chan struct{}),
+		writeCancelCh: make(chan struct{}),
+	}
+}
+
+// set arms timer/cancelCh so cancelCh closes at t. A zero t means "no
+// deadline": any pending timer is stopped and cancelCh is left armed
+// but unfired. If the previous timer had already fired, its cancelCh is
+// replaced with a fresh one so future requests aren't canceled
+// instantly.
+func (dm *deadlineManager) set(timer **time.Timer, cancelCh *chan struct{}, t time.Time) {
+	dm.mu.Lock()
+	defer dm.mu.Unlock()
+
+	if *timer != nil {
+		(*timer).Stop()
+	}
+
+	select {
+	case <-*cancelCh:
+		*cancelCh = make(chan struct{})
+	default:
+	}
+
+	if t.IsZero() {
+		*timer = nil
+		return
+	}
+
+	ch := *cancelCh
+	*timer = time.AfterFunc(time.Until(t), func() {
+		close(ch)
+	})
+}
+
+func (dm *deadlineManager) currentReadCh() chan struct{} {
+	dm.mu.Lock()
+	defer dm.mu.Unlock()
+	return dm.readCancelCh
+}
+
+func (dm *deadlineManager) currentWriteCh() chan struct{} {
+	dm.mu.Lock()
+	defer dm.mu.Unlock()
+	return dm.writeCancelCh
+}
+
+// SetReadDeadline arms a rolling deadline for read requests (HTTP GET):
+// when t passes, every read request then in flight is canceled, and
+// later read requests are canceled at once until the deadline is moved
+// again. A zero t clears the deadline.
+func (c *CreditDB) SetReadDeadline(t time.Time) {
+	c.deadlines.set(&c.deadlines.readTimer, &c.deadlines.readCancelCh, t)
+}
+
+// SetWriteDeadline arms a rolling deadline for write requests (HTTP
+// POST/DELETE): when t passes, every write request then in flight is
+// canceled, and later write requests are canceled at once until the
+// deadline is moved again. A zero t clears the deadline.
+func (c *CreditDB) SetWriteDeadline(t time.Time) {
+	c.deadlines.set(&c.deadlines.writeTimer, &c.deadlines.writeCancelCh, t)
+}
+
+// withDeadline wraps ctx so it is also canceled when the manager's
+// current cancel channel for cancelCh fires, and returns a cleanup func
+// the caller must invoke once the request is complete.
+func withDeadline(ctx context.Context, cancelCh chan struct{}) (context.Context, func()) {
+	derived, cancel := context.WithCancel(ctx)
+	done := make(chan struct{})
+	go func() {
+		select {
+		case <-cancelCh:
+			cancel()
+		case <-done:
+		}
+	}()
+	return derived, func() {
+		close(done)
+		cancel()
+	}
+}
+
+// deadlineContext derives a context for a request of the given HTTP
+// method, applying the read deadline to GETs and the write deadline to
+// everything else.
+func (c *CreditDB) deadlineContext(ctx context.Context, method string) (context.Context, func()) {
+	if method == "GET" {
+		return withDeadline(ctx, c.deadlines.currentReadCh())
+	}
+	return withDeadline(ctx, c.deadlines.currentWriteCh())
+}
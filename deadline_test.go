@@ -0,0 +1,90 @@
+package creditdb
+
+import (
+	"context"
+	"testing"
+	"time"
+)
+
+func TestSetReadDeadlineCancelsSubsequentRequests(t *testing.T) {
+	dm := newDeadlineManager()
+	dm.set(&dm.readTimer, &dm.readCancelCh, time.Now().Add(20*time.Millisecond))
+
+	ctx, cancel := withDeadline(context.Background(), dm.currentReadCh())
+	defer cancel()
+
+	select {
+	case <-ctx.Done():
+		t.Fatal("context was canceled before the deadline elapsed")
+	case <-time.After(5 * time.Millisecond):
+	}
+
+	select {
+	case <-ctx.Done():
+	case <-time.After(200 * time.Millisecond):
+		t.Fatal("expected the context to be canceled once the read deadline elapsed")
+	}
+}
+
+func TestZeroDeadlineClearsTimer(t *testing.T) {
+	dm := newDeadlineManager()
+	dm.set(&dm.readTimer, &dm.readCancelCh, time.Now().Add(10*time.Millisecond))
+	dm.set(&dm.readTimer, &dm.readCancelCh, time.Time{})
+
+	ctx, cancel := withDeadline(context.Background(), dm.currentReadCh())
+	defer cancel()
+
+	select {
+	case <-ctx.Done():
+		t.Fatal("context was canceled even though the deadline was cleared with a zero time")
+	case <-time.After(50 * time.Millisecond):
+	}
+}
+
+func TestDeadlineManagerReplacesAlreadyFiredChannel(t *testing.T) {
+	dm := newDeadlineManager()
+	dm.set(&dm.writeTimer, &dm.writeCancelCh, time.Now().Add(5*time.Millisecond))
+
+	time.Sleep(20 * time.Millisecond)
+	select {
+	case <-dm.currentWriteCh():
+	default:
+		t.Fatal("expected the first cancel channel to have already fired")
+	}
+
+	dm.set(&dm.writeTimer, &dm.writeCancelCh, time.Now().Add(50*time.Millisecond))
+	ch := dm.currentWriteCh()
+
+	select {
+	case <-ch:
+		t.Fatal("expected the replacement channel to be unfired immediately after Set")
+	default:
+	}
+
+	select {
+	case <-ch:
+	case <-time.After(200 * time.Millisecond):
+		t.Fatal("expected the replacement channel to fire once its own deadline elapsed")
+	}
+}
+
+func TestDeadlineContextAppliesReadDeadlineToGETOnly(t *testing.T) {
+	c := testClient("http://example.invalid")
+	c.SetReadDeadline(time.Now().Add(5 * time.Millisecond))
+
+	readCtx, cancel := c.deadlineContext(context.Background(), "GET")
+	defer cancel()
+	select {
+	case <-readCtx.Done():
+	case <-time.After(200 * time.Millisecond):
+		t.Fatal("expected a GET context to be canceled by the read deadline")
+	}
+
+	writeCtx, cancel2 := c.deadlineContext(context.Background(), "POST")
+	defer cancel2()
+	select {
+	case <-writeCtx.Done():
+		t.Fatal("a POST context should not be canceled by the read deadline")
+	case <-time.After(50 * time.Millisecond):
+	}
+}
@@ -1,28 +1,128 @@
 package creditdb
 
-import "net/http"
+import (
+	"context"
+	"errors"
+	"net"
+	"net/http"
+)
+
+// ErrorCode is a small stable numeric identifier for an Error's
+// Category, suitable for wire transport or metrics labels where a
+// string comparison would be wasteful.
+type ErrorCode int
+
+const (
+	CodeNotFound ErrorCode = iota + 1
+	CodeBadRequest
+	CodeTimeout
+	CodeServiceUnavailable
+	CodeInternal
+	CodeConflict
+	CodeUnauthorized
+)
 
+const (
+	CategoryNotFound           = "NotFound"
+	CategoryBadRequest         = "BadRequest"
+	CategoryTimeout            = "Timeout"
+	CategoryServiceUnavailable = "ServiceUnavailable"
+	CategoryInternalError      = "InternalError"
+	CategoryConflict           = "Conflict"
+	CategoryUnauthorized       = "Unauthorized"
+)
+
+func codeForCategory(category string) ErrorCode {
+	switch category {
+	case CategoryNotFound:
+		return CodeNotFound
+	case CategoryBadRequest:
+		return CodeBadRequest
+	case CategoryTimeout:
+		return CodeTimeout
+	case CategoryServiceUnavailable:
+		return CodeServiceUnavailable
+	case CategoryConflict:
+		return CodeConflict
+	case CategoryUnauthorized:
+		return CodeUnauthorized
+	default:
+		return CodeInternal
+	}
+}
+
+// Error is the error type returned by every CreditDB method. Alongside
+// the Category it was originally built from, it can carry the request
+// context (URL, method, HTTP status, retry attempt) and original
+// transport error that produced it, while still matching the package's
+// sentinel values via errors.Is.
 type Error struct {
 	Message  string
 	Category string
+	Code     ErrorCode
+
+	Cause      error
+	URL        string
+	Method     string
+	HTTPStatus int
+	Attempt    int
 }
 
-const (
-	CategoryNotFound   = "NotFound"
-	CategoryBadRequest = "BadRequest"
-	CategoryTimeout = "Timeout"
-	CategoryServiceUnavailable = "ServiceUnavailable"
-	CategoryInternalError = "InternalError"
-)
+func NewError(message string, category string) *Error {
+	return &Error{Message: message, Category: category, Code: codeForCategory(category)}
+}
 
 func (e *Error) Error() string {
 	return e.Message
 }
 
-func NewError(message string, category string) *Error {
-	return &Error{Message: message, Category: category}
+// Is reports whether target is a sentinel *Error with the same Code, so
+// errors.Is(err, ErrNotFound) matches even after err has been wrapped
+// with request context via WithCause/WithRequest/WithAttempt.
+func (e *Error) Is(target error) bool {
+	t, ok := target.(*Error)
+	if !ok {
+		return false
+	}
+	return e.Code == t.Code
+}
+
+// Unwrap exposes the original cause, if any, so errors.As can reach it
+// through this Error.
+func (e *Error) Unwrap() error {
+	return e.Cause
+}
+
+// WithCause returns a copy of e with its Cause set to cause.
+func (e *Error) WithCause(cause error) *Error {
+	clone := *e
+	clone.Cause = cause
+	return &clone
+}
+
+// WithRequest returns a copy of e annotated with the URL, method, and
+// HTTP status of the request that produced it.
+func (e *Error) WithRequest(req *http.Request, statusCode int) *Error {
+	clone := *e
+	if req != nil {
+		clone.URL = req.URL.String()
+		clone.Method = req.Method
+	}
+	clone.HTTPStatus = statusCode
+	return &clone
 }
 
+// WithAttempt returns a copy of e recording which retry attempt
+// (1-indexed) produced it.
+func (e *Error) WithAttempt(attempt int) *Error {
+	clone := *e
+	clone.Attempt = attempt
+	return &clone
+}
+
+// StatusCode returns the HTTP status code conventionally associated
+// with e's Category, for servers or callers that need to answer "what
+// status would this error be reported as".
 func (e *Error) StatusCode() int {
 	switch e.Category {
 	case CategoryNotFound:
@@ -33,8 +133,53 @@ func (e *Error) StatusCode() int {
 		return http.StatusRequestTimeout
 	case CategoryServiceUnavailable:
 		return http.StatusServiceUnavailable
+	case CategoryConflict:
+		return http.StatusConflict
+	case CategoryUnauthorized:
+		return http.StatusUnauthorized
 	default:
 		return http.StatusInternalServerError
 	}
+}
+
+// isTimeoutErr reports whether err represents a transport-level timeout
+// (a net.Error with Timeout() true, or a context.DeadlineExceeded)
+// rather than some other transport failure.
+func isTimeoutErr(err error) bool {
+	var netErr net.Error
+	if errors.As(err, &netErr) && netErr.Timeout() {
+		return true
+	}
+	return errors.Is(err, context.DeadlineExceeded)
+}
 
+// Classify centralizes the mapping from a transport error or HTTP
+// response to the package's typed Error, so every endpoint reuses the
+// same rules instead of re-deriving them at each callsite.
+func Classify(resp *http.Response, err error) *Error {
+	if err != nil {
+		if isTimeoutErr(err) {
+			return NewError("timeout", CategoryTimeout).WithCause(err)
+		}
+		return NewError("request failed", CategoryInternalError).WithCause(err)
+	}
+	if resp == nil {
+		return NewError("internal server error", CategoryInternalError)
+	}
+	switch resp.StatusCode {
+	case http.StatusNotFound:
+		return ErrNotFound
+	case http.StatusBadRequest:
+		return ErrBadRequest
+	case http.StatusRequestTimeout, http.StatusGatewayTimeout:
+		return ErrTimeout
+	case http.StatusServiceUnavailable, http.StatusBadGateway:
+		return ErrServiceUnavailable
+	case http.StatusConflict:
+		return ErrConflict
+	case http.StatusUnauthorized:
+		return ErrUnauthorized
+	default:
+		return ErrInternalError
+	}
 }
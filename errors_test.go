@@ -0,0 +1,69 @@
+package creditdb
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"testing"
+)
+
+func TestErrorAsReachesWrappedCause(t *testing.T) {
+	cause := errors.New("dial tcp: connection refused")
+	err := NewError("request failed", CategoryInternalError).WithCause(cause)
+
+	var target *Error
+	if !errors.As(err, &target) {
+		t.Fatalf("expected errors.As to match *Error")
+	}
+	if !errors.Is(target.Unwrap(), cause) {
+		t.Fatalf("expected Unwrap to return the original cause, got %v", target.Unwrap())
+	}
+	if !errors.Is(err, err) {
+		t.Fatalf("expected an *Error to be errors.Is itself")
+	}
+}
+
+func TestClassifyMapsStatusCodesToSentinels(t *testing.T) {
+	tests := []struct {
+		status int
+		want   *Error
+	}{
+		{http.StatusNotFound, ErrNotFound},
+		{http.StatusBadRequest, ErrBadRequest},
+		{http.StatusRequestTimeout, ErrTimeout},
+		{http.StatusGatewayTimeout, ErrTimeout},
+		{http.StatusServiceUnavailable, ErrServiceUnavailable},
+		{http.StatusBadGateway, ErrServiceUnavailable},
+		{http.StatusConflict, ErrConflict},
+		{http.StatusUnauthorized, ErrUnauthorized},
+		{http.StatusTeapot, ErrInternalError},
+	}
+
+	for _, tt := range tests {
+		resp := &http.Response{StatusCode: tt.status}
+		got := Classify(resp, nil)
+		if !errors.Is(got, tt.want) {
+			t.Fatalf("Classify(%d) = %v, want to match %v", tt.status, got, tt.want)
+		}
+		if got.Code != tt.want.Code {
+			t.Fatalf("Classify(%d).Code = %v, want %v", tt.status, got.Code, tt.want.Code)
+		}
+	}
+}
+
+func TestClassifyTransportErrors(t *testing.T) {
+	timeoutErr := Classify(nil, context.DeadlineExceeded)
+	if !errors.Is(timeoutErr, ErrTimeout) {
+		t.Fatalf("expected a DeadlineExceeded transport error to classify as ErrTimeout, got %v", timeoutErr)
+	}
+
+	otherErr := Classify(nil, errors.New("connection reset by peer"))
+	if !errors.Is(otherErr, ErrInternalError) {
+		t.Fatalf("expected a generic transport error to classify as ErrInternalError, got %v", otherErr)
+	}
+
+	nilRespErr := Classify(nil, nil)
+	if !errors.Is(nilRespErr, ErrInternalError) {
+		t.Fatalf("expected a nil response with no error to classify as ErrInternalError, got %v", nilRespErr)
+	}
+}
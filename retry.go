@@ -0,0 +1,117 @@
+package creditdb
+
+import (
+	"context"
+	"net/http"
+	"time"
+
+	"github.com/cenkalti/backoff/v4"
+)
+
+// RetryPolicy controls the backoff schedule doWithRetry uses when a
+// request fails with a transient error.
+type RetryPolicy struct {
+	MaxAttempts     int
+	InitialInterval time.Duration
+	MaxInterval     time.Duration
+	MaxElapsedTime  time.Duration
+}
+
+var defaultRetryPolicy = RetryPolicy{
+	MaxAttempts:     3,
+	InitialInterval: 100 * time.Millisecond,
+	MaxInterval:     2 * time.Second,
+	MaxElapsedTime:  5 * time.Second,
+}
+
+// WithRetry overrides the backoff policy every method on c routes
+// through. It is most useful in tests that want to shrink the backoff
+// window so a flaky httptest.Server doesn't slow the suite down.
+func (c *CreditDB) WithRetry(policy RetryPolicy) *CreditDB {
+	c.retry = policy
+	return c
+}
+
+// WithHTTPClient overrides the http.Client used to issue requests, for
+// example to point the client at an httptest.Server or to install a
+// custom transport.
+func (c *CreditDB) WithHTTPClient(client *http.Client) *CreditDB {
+	c.client = client
+	return c
+}
+
+func isRetryableStatus(status int) bool {
+	switch status {
+	case http.StatusRequestTimeout, http.StatusInternalServerError, http.StatusBadGateway, http.StatusServiceUnavailable, http.StatusGatewayTimeout:
+		return true
+	default:
+		return false
+	}
+}
+
+func isRetryableErr(err error) bool {
+	return isTimeoutErr(err)
+}
+
+// doWithRetry builds and issues a request via newReq, retrying according
+// to c.retry whenever the transport error or response status looks
+// transient. On a final StatusCode match it runs decode (when non-nil)
+// against the response body; any other outcome is classified via
+// Classify and annotated with the request and attempt that produced it.
+func (c *CreditDB) doWithRetry(ctx context.Context, newReq func() (*http.Request, error), wantStatus int, decode func(*http.Response) error) error {
+	b := backoff.NewExponentialBackOff()
+	b.InitialInterval = c.retry.InitialInterval
+	b.MaxInterval = c.retry.MaxInterval
+	b.MaxElapsedTime = c.retry.MaxElapsedTime
+
+	attempts := 0
+	var lastErr *Error
+
+	operation := func() error {
+		attempts++
+		req, err := newReq()
+		if err != nil {
+			lastErr = NewError("failed to build request", CategoryBadRequest).WithCause(err)
+			return backoff.Permanent(lastErr)
+		}
+
+		reqCtx, cancel := c.deadlineContext(req.Context(), req.Method)
+		defer cancel()
+		req = req.WithContext(reqCtx)
+
+		resp, err := c.client.Do(req)
+		if err != nil {
+			lastErr = Classify(nil, err).WithRequest(req, 0).WithAttempt(attempts)
+			if isRetryableErr(err) && attempts < c.retry.MaxAttempts {
+				return err
+			}
+			return backoff.Permanent(lastErr)
+		}
+		defer resp.Body.Close()
+
+		if resp.StatusCode != wantStatus {
+			lastErr = Classify(resp, nil).WithRequest(req, resp.StatusCode).WithAttempt(attempts)
+			if isRetryableStatus(resp.StatusCode) && attempts < c.retry.MaxAttempts {
+				return lastErr
+			}
+			return backoff.Permanent(lastErr)
+		}
+
+		if decode != nil {
+			if err := decode(resp); err != nil {
+				lastErr = NewError("failed to decode response", CategoryInternalError).WithCause(err).WithRequest(req, resp.StatusCode).WithAttempt(attempts)
+				return backoff.Permanent(lastErr)
+			}
+		}
+		lastErr = nil
+		return nil
+	}
+
+	if err := backoff.Retry(operation, backoff.WithContext(b, ctx)); err != nil {
+		if lastErr != nil {
+			return lastErr
+		}
+		return NewError("request failed", CategoryInternalError).WithCause(err)
+	}
+	return nil
+}
@@ -0,0 +1,116 @@
+package creditdb
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+func testClient(host string) *CreditDB {
+	return &CreditDB{
+		config:    config{host: host, currentPage: defaultPage},
+		client:    &http.Client{},
+		retry:     RetryPolicy{MaxAttempts: 3, InitialInterval: time.Millisecond, MaxInterval: 5 * time.Millisecond, MaxElapsedTime: time.Second},
+		deadlines: newDeadlineManager(),
+	}
+}
+
+func TestDoWithRetrySucceedsAfterNFailures(t *testing.T) {
+	var attempts int32
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if atomic.AddInt32(&attempts, 1) <= 2 {
+			w.WriteHeader(http.StatusServiceUnavailable)
+			return
+		}
+		w.WriteHeader(http.StatusOK)
+	}))
+	defer srv.Close()
+
+	c := testClient(srv.URL)
+	err := c.doWithRetry(context.Background(), func() (*http.Request, error) {
+		return http.NewRequest("GET", srv.URL, nil)
+	}, http.StatusOK, nil)
+
+	if err != nil {
+		t.Fatalf("expected success after retries, got %v", err)
+	}
+	if got := atomic.LoadInt32(&attempts); got != 3 {
+		t.Fatalf("expected 3 attempts, got %d", got)
+	}
+}
+
+func TestDoWithRetryDoesNotRetryPermanentStatus(t *testing.T) {
+	var attempts int32
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		atomic.AddInt32(&attempts, 1)
+		w.WriteHeader(http.StatusBadRequest)
+	}))
+	defer srv.Close()
+
+	c := testClient(srv.URL)
+	err := c.doWithRetry(context.Background(), func() (*http.Request, error) {
+		return http.NewRequest("GET", srv.URL, nil)
+	}, http.StatusOK, nil)
+
+	if !errors.Is(err, ErrBadRequest) {
+		t.Fatalf("expected ErrBadRequest, got %v", err)
+	}
+	if got := atomic.LoadInt32(&attempts); got != 1 {
+		t.Fatalf("expected exactly 1 attempt for a non-retryable status, got %d", got)
+	}
+}
+
+func TestDoWithRetryExhaustsAttemptsAsTimeout(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		time.Sleep(50 * time.Millisecond)
+		w.WriteHeader(http.StatusOK)
+	}))
+	defer srv.Close()
+
+	c := testClient(srv.URL)
+	c.client = &http.Client{Timeout: 5 * time.Millisecond}
+
+	err := c.doWithRetry(context.Background(), func() (*http.Request, error) {
+		return http.NewRequest("GET", srv.URL, nil)
+	}, http.StatusOK, nil)
+
+	if !errors.Is(err, ErrTimeout) {
+		t.Fatalf("expected ErrTimeout, got %v", err)
+	}
+	if errors.Is(err, ErrInternalError) {
+		t.Fatalf("timeout should not also classify as ErrInternalError: %v", err)
+	}
+}
+
+func TestDoWithRetryStopsOnContextCancellation(t *testing.T) {
+	var attempts int32
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		atomic.AddInt32(&attempts, 1)
+		w.WriteHeader(http.StatusServiceUnavailable)
+	}))
+	defer srv.Close()
+
+	c := testClient(srv.URL)
+	c.retry.MaxAttempts = 100
+
+	ctx, cancel := context.WithCancel(context.Background())
+	go func() {
+		time.Sleep(20 * time.Millisecond)
+		cancel()
+	}()
+
+	err := c.doWithRetry(ctx, func() (*http.Request, error) {
+		return http.NewRequest("GET", srv.URL, nil)
+	}, http.StatusOK, nil)
+
+	if err == nil {
+		t.Fatal("expected an error once the context is canceled mid-backoff")
+	}
+	if got := atomic.LoadInt32(&attempts); got >= 100 {
+		t.Fatalf("expected cancellation to cut retries short, got %d attempts", got)
+	}
+}
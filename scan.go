@@ -0,0 +1,197 @@
+package creditdb
+
+import (
+	"bufio"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strconv"
+)
+
+// ScanOptions configures a Scan call.
+type ScanOptions struct {
+	// Limit caps how many entries the server returns per page. Zero
+	// means the server's own default page size.
+	Limit int
+}
+
+type scanResponse struct {
+	Status     string `json:"status"`
+	Result     []Line `json:"result"`
+	NextCursor string `json:"cursor"`
+}
+
+// Iterator streams GetAllLines results a page at a time via the
+// server's opaque cursor instead of buffering the whole dataset in
+// memory.
+type Iterator struct {
+	c       *CreditDB
+	ctx     context.Context
+	opts    ScanOptions
+	cursor  string
+	done    bool
+	buf     []Line
+	current Line
+	err     error
+}
+
+// Scan returns an Iterator over the current page, fetched lazily as
+// Next is called.
+func (c *CreditDB) Scan(ctx context.Context, opts ScanOptions) (*Iterator, error) {
+	return &Iterator{c: c, ctx: ctx, opts: opts}, nil
+}
+
+// Next advances the iterator, fetching the next page from the server
+// once the current one is exhausted. It returns false once the cursor
+// is exhausted or an error occurs; check Err afterward to tell them
+// apart.
+func (it *Iterator) Next() bool {
+	if it.err != nil {
+		return false
+	}
+	for len(it.buf) == 0 {
+		if it.done {
+			return false
+		}
+		if err := it.fetch(); err != nil {
+			it.err = err
+			return false
+		}
+	}
+	it.current, it.buf = it.buf[0], it.buf[1:]
+	return true
+}
+
+func (it *Iterator) fetch() error {
+	scanURL := fmt.Sprintf("%s/scan", it.c.config.host)
+	u, err := url.Parse(scanURL)
+	if err != nil {
+		return ErrInternalError
+	}
+	q := u.Query()
+	q.Set("cursor", it.cursor)
+	if it.opts.Limit != 0 {
+		q.Set("limit", strconv.Itoa(it.opts.Limit))
+	}
+	u.RawQuery = q.Encode()
+	scanURL = u.String()
+
+	var response scanResponse
+	err = it.c.doWithRetry(it.ctx, func() (*http.Request, error) {
+		return http.NewRequestWithContext(it.ctx, "GET", scanURL, nil)
+	}, http.StatusOK, func(resp *http.Response) error {
+		return json.NewDecoder(resp.Body).Decode(&response)
+	})
+	if err != nil {
+		return err
+	}
+	if response.Status != "OK" {
+		return ErrBadRequest
+	}
+	it.buf = response.Result
+	it.cursor = response.NextCursor
+	if it.cursor == "" {
+		it.done = true
+	}
+	return nil
+}
+
+// Line returns the entry Next just advanced to.
+func (it *Iterator) Line() Line {
+	return it.current
+}
+
+// Err returns the error, if any, that stopped iteration.
+func (it *Iterator) Err() error {
+	return it.err
+}
+
+// Close releases resources held by the iterator. It is safe to call
+// more than once.
+func (it *Iterator) Close() error {
+	it.done = true
+	return nil
+}
+
+// DumpOptions restricts a Dump to a key range.
+type DumpOptions struct {
+	// From and To bound the dump to keys in [From, To). Empty means
+	// unbounded on that side.
+	From string
+	To   string
+}
+
+func inDumpRange(key string, opts DumpOptions) bool {
+	if opts.From != "" && key < opts.From {
+		return false
+	}
+	if opts.To != "" && key >= opts.To {
+		return false
+	}
+	return true
+}
+
+type countingWriter struct {
+	w io.Writer
+	n int64
+}
+
+func (cw *countingWriter) Write(p []byte) (int, error) {
+	n, err := cw.w.Write(p)
+	cw.n += int64(n)
+	return n, err
+}
+
+// Dump streams every line on the current page whose key lies in opts'
+// range to w as newline-delimited JSON, one Line per line, without ever materializing the whole dataset
+// in memory. It returns the number of bytes written.
+func (c *CreditDB) Dump(ctx context.Context, w io.Writer, opts DumpOptions) (int64, error) {
+	it, err := c.Scan(ctx, ScanOptions{})
+	if err != nil {
+		return 0, err
+	}
+	defer it.Close()
+
+	cw := &countingWriter{w: w}
+	enc := json.NewEncoder(cw)
+	for it.Next() {
+		line := it.Line()
+		if !inDumpRange(line.Key, opts) {
+			continue
+		}
+		if err := enc.Encode(line); err != nil {
+			return cw.n, ErrInternalError
+		}
+	}
+	if it.Err() != nil {
+		return cw.n, it.Err()
+	}
+	return cw.n, nil
+}
+
+// Restore reads newline-delimited JSON Line records from r, streaming
+// them into SetLine one at a time rather than buffering the whole
+// input.
+func (c *CreditDB) Restore(ctx context.Context, r io.Reader) error {
+	scanner := bufio.NewScanner(r)
+	for scanner.Scan() {
+		text := scanner.Bytes()
+		if len(text) == 0 {
+			continue
+		}
+		var line Line
+		if err := json.Unmarshal(text, &line); err != nil {
+			return ErrBadRequest
+		}
+		if err := c.SetLine(ctx, line.Key, line.Value); err != nil {
+			return err
+		}
+	}
+	if err := scanner.Err(); err != nil {
+		return ErrInternalError
+	}
+	return nil
+}
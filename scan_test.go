@@ -0,0 +1,111 @@
+package creditdb
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"strings"
+	"testing"
+)
+
+func TestScanCursorIsEscaped(t *testing.T) {
+	var gotCursor string
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		gotCursor = r.URL.Query().Get("cursor")
+		w.Header().Set("Content-Type", "application/json")
+		w.Write([]byte(`{"status":"OK","result":[],"cursor":""}`))
+	}))
+	defer srv.Close()
+
+	c := testClient(srv.URL)
+	cursorVal := "ab+cd=ef&evil=1"
+	it := &Iterator{c: c, ctx: context.Background(), opts: ScanOptions{}, cursor: cursorVal}
+	it.Next()
+
+	if gotCursor != cursorVal {
+		t.Fatalf("server observed cursor %q, want %q (the raw value must survive URL encoding)", gotCursor, cursorVal)
+	}
+}
+
+func TestFetchOmitsLimitWhenZero(t *testing.T) {
+	var gotQuery url.Values
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		gotQuery = r.URL.Query()
+		w.Header().Set("Content-Type", "application/json")
+		w.Write([]byte(`{"status":"OK","result":[],"cursor":""}`))
+	}))
+	defer srv.Close()
+
+	c := testClient(srv.URL)
+	it := &Iterator{c: c, ctx: context.Background(), opts: ScanOptions{}}
+	it.Next()
+
+	if gotQuery.Has("limit") {
+		t.Fatalf("expected no limit param for the zero-value default, got %q", gotQuery.Get("limit"))
+	}
+
+	it2 := &Iterator{c: c, ctx: context.Background(), opts: ScanOptions{Limit: 50}}
+	it2.Next()
+
+	if got := gotQuery.Get("limit"); got != "50" {
+		t.Fatalf("expected limit=50 when ScanOptions.Limit is set, got %q", got)
+	}
+}
+
+func TestDumpStreamsAllPages(t *testing.T) {
+	pages := [][]Line{
+		{{Key: "a", Value: "1"}, {Key: "b", Value: "2"}},
+		{{Key: "c", Value: "3"}},
+	}
+	call := 0
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		resp := scanResponse{Status: "OK", Result: pages[call]}
+		call++
+		if call < len(pages) {
+			resp.NextCursor = "more"
+		}
+		w.Header().Set("Content-Type", "application/json")
+		json.NewEncoder(w).Encode(resp)
+	}))
+	defer srv.Close()
+
+	c := testClient(srv.URL)
+	var buf bytes.Buffer
+	n, err := c.Dump(context.Background(), &buf, DumpOptions{})
+	if err != nil {
+		t.Fatalf("Dump returned error: %v", err)
+	}
+	if n != int64(buf.Len()) {
+		t.Fatalf("Dump reported %d bytes written, buffer has %d", n, buf.Len())
+	}
+
+	lines := strings.Split(strings.TrimSpace(buf.String()), "\n")
+	if len(lines) != 3 {
+		t.Fatalf("expected 3 dumped lines across both pages, got %d: %q", len(lines), buf.String())
+	}
+}
+
+func TestRestoreReplaysEachLine(t *testing.T) {
+	var gotKeys []string
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var line Line
+		json.NewDecoder(r.Body).Decode(&line)
+		gotKeys = append(gotKeys, line.Key)
+		w.WriteHeader(http.StatusOK)
+	}))
+	defer srv.Close()
+
+	c := testClient(srv.URL)
+	input := strings.NewReader(`{"key":"a","value":"1"}
+{"key":"b","value":"2"}
+`)
+	if err := c.Restore(context.Background(), input); err != nil {
+		t.Fatalf("Restore returned error: %v", err)
+	}
+	if len(gotKeys) != 2 || gotKeys[0] != "a" || gotKeys[1] != "b" {
+		t.Fatalf("expected SetLine to be called for keys [a b] in order, got %v", gotKeys)
+	}
+}
@@ -0,0 +1,274 @@
+package creditdb
+
+import (
+	"bufio"
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/cenkalti/backoff/v4"
+)
+
+// EventType identifies the kind of change a Watch Event reports.
+type EventType string
+
+const (
+	EventSet    EventType = "set"
+	EventDelete EventType = "delete"
+	EventFlush  EventType = "flush"
+)
+
+// Event describes a single key change observed by Watch.
+type Event struct {
+	Type  EventType `json:"type"`
+	Key   string    `json:"key"`
+	Value string    `json:"value"`
+	Page  uint      `json:"page"`
+	At    time.Time `json:"at"`
+}
+
+// WatchOptions configures a Watch subscription.
+type WatchOptions struct {
+	// Prefix restricts the subscription to keys sharing this prefix;
+	// empty means every key on the current page.
+	Prefix string
+	// IncludeInitial emits a synthetic EventSet for every key that
+	// already exists on the page at subscription time.
+	IncludeInitial bool
+}
+
+// watchPollInterval is how often Watch re-polls GetAllLines when the
+// server doesn't support the SSE /watch route. It is a var rather than
+// a const so tests can shrink it.
+var watchPollInterval = 2 * time.Second
+
+// Watch subscribes to change notifications for keys (or every key on
+// the current page, when keys is empty). See WatchWithOptions for the
+// full signature.
+func (c *CreditDB) Watch(ctx context.Context, keys ...string) (<-chan Event, error) {
+	return c.WatchWithOptions(ctx, WatchOptions{}, keys...)
+}
+
+// WatchWithOptions subscribes to change notifications for keys. It
+// prefers the server's /watch Server-Sent-Events stream, reconnecting
+// with the same exponential backoff intervals NewClient uses for its
+// health probe (but with no cap on total elapsed time) and resuming via Last-Event-ID so no events are lost across
+// reconnects. When the server doesn't support SSE (404, or a
+// non-event-stream content type), Watch transparently falls back to
+// polling GetAllLines and diffing snapshots. The returned channel is
+// closed, and the background goroutine torn down, when ctx is canceled.
+func (c *CreditDB) WatchWithOptions(ctx context.Context, opts WatchOptions, keys ...string) (<-chan Event, error) {
+	events := make(chan Event)
+
+	go func() {
+		defer close(events)
+		if opts.IncludeInitial {
+			c.emitInitial(ctx, events, opts, keys)
+		}
+		c.runWatch(ctx, events, opts, keys)
+	}()
+
+	return events, nil
+}
+
+func watchMatches(key string, opts WatchOptions, keys []string) bool {
+	if opts.Prefix != "" && !strings.HasPrefix(key, opts.Prefix) {
+		return false
+	}
+	if len(keys) == 0 {
+		return true
+	}
+	for _, k := range keys {
+		if k == key {
+			return true
+		}
+	}
+	return false
+}
+
+func (c *CreditDB) emitInitial(ctx context.Context, events chan<- Event, opts WatchOptions, keys []string) {
+	lines, err := c.GetAllLines(ctx)
+	if err != nil {
+		return
+	}
+	for _, line := range lines {
+		if !watchMatches(line.Key, opts, keys) {
+			continue
+		}
+		select {
+		case events <- Event{Type: EventSet, Key: line.Key, Value: line.Value, Page: c.config.currentPage, At: time.Now()}:
+		case <-ctx.Done():
+			return
+		}
+	}
+}
+
+// runWatch drives the SSE connection, reconnecting on error until ctx
+// is canceled or the server signals it doesn't support SSE at all, in
+// which case it hands off to the polling fallback.
+func (c *CreditDB) runWatch(ctx context.Context, events chan<- Event, opts WatchOptions, keys []string) {
+	lastEventID := ""
+	b := backoff.NewExponentialBackOff()
+	b.InitialInterval = 100 * time.Millisecond
+	b.MaxInterval = 30 * time.Second
+	b.MaxElapsedTime = 0
+
+	for {
+		if ctx.Err() != nil {
+			return
+		}
+		supported, nextID, err := c.watchOnce(ctx, events, opts, keys, lastEventID)
+		if ctx.Err() != nil {
+			return
+		}
+		if !supported {
+			c.watchPoll(ctx, events, opts, keys)
+			return
+		}
+		lastEventID = nextID
+		if err == nil {
+			b.Reset()
+			continue
+		}
+		select {
+		case <-ctx.Done():
+			return
+		case <-time.After(b.NextBackOff()):
+		}
+	}
+}
+
+// watchOnce opens a single SSE connection and streams events from it
+// until the connection drops or ctx is canceled. supported reports
+// whether the server understands /watch as SSE at all; when false, the
+// caller should stop retrying SSE and fall back to polling.
+func (c *CreditDB) watchOnce(ctx context.Context, events chan<- Event, opts WatchOptions, keys []string, lastEventID string) (supported bool, nextEventID string, err error) {
+	watchURL := fmt.Sprintf("%s/watch", c.config.host)
+	req, err := http.NewRequestWithContext(ctx, "GET", watchURL, nil)
+	if err != nil {
+		return true, lastEventID, err
+	}
+	req.Header.Set("Accept", "text/event-stream")
+	if lastEventID != "" {
+		req.Header.Set("Last-Event-ID", lastEventID)
+	}
+
+	resp, err := c.client.Do(req)
+	if err != nil {
+		return true, lastEventID, err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode == http.StatusNotFound || !strings.HasPrefix(resp.Header.Get("Content-Type"), "text/event-stream") {
+		return false, lastEventID, nil
+	}
+	if resp.StatusCode != http.StatusOK {
+		return true, lastEventID, ErrInternalError
+	}
+
+	eventID := lastEventID
+	eventName := ""
+	var dataLines []string
+
+	flush := func() {
+		if len(dataLines) == 0 {
+			return
+		}
+		var payload Event
+		if jsonErr := json.Unmarshal([]byte(strings.Join(dataLines, "\n")), &payload); jsonErr == nil {
+			if eventName != "" {
+				payload.Type = EventType(eventName)
+			}
+			if watchMatches(payload.Key, opts, keys) {
+				select {
+				case events <- payload:
+				case <-ctx.Done():
+				}
+			}
+		}
+		dataLines = nil
+		eventName = ""
+	}
+
+	scanner := bufio.NewScanner(resp.Body)
+	for scanner.Scan() {
+		if ctx.Err() != nil {
+			return true, eventID, ctx.Err()
+		}
+		line := scanner.Text()
+		switch {
+		case line == "":
+			flush()
+		case strings.HasPrefix(line, "id:"):
+			eventID = strings.TrimSpace(strings.TrimPrefix(line, "id:"))
+		case strings.HasPrefix(line, "event:"):
+			eventName = strings.TrimSpace(strings.TrimPrefix(line, "event:"))
+		case strings.HasPrefix(line, "data:"):
+			dataLines = append(dataLines, strings.TrimSpace(strings.TrimPrefix(line, "data:")))
+		}
+	}
+	flush()
+	if err := scanner.Err(); err != nil {
+		return true, eventID, err
+	}
+	return true, eventID, nil
+}
+
+// watchPoll synthesizes Events by polling GetAllLines on an interval
+// and diffing successive snapshots, for servers that don't support SSE.
+// The snapshot is primed with a GetAllLines call before the first diff
+// so pre-existing keys aren't reported as new arrivals; WatchOptions's
+// IncludeInitial (handled by emitInitial) is the only source of
+// synthetic events for keys that already existed at subscription time.
+func (c *CreditDB) watchPoll(ctx context.Context, events chan<- Event, opts WatchOptions, keys []string) {
+	ticker := time.NewTicker(watchPollInterval)
+	defer ticker.Stop()
+
+	snapshot := map[string]string{}
+	if lines, err := c.GetAllLines(ctx); err == nil {
+		for _, line := range lines {
+			snapshot[line.Key] = line.Value
+		}
+	}
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+
+		lines, err := c.GetAllLines(ctx)
+		if err != nil {
+			continue
+		}
+
+		seen := make(map[string]string, len(lines))
+		for _, line := range lines {
+			seen[line.Key] = line.Value
+			if !watchMatches(line.Key, opts, keys) {
+				continue
+			}
+			if old, ok := snapshot[line.Key]; !ok || old != line.Value {
+				select {
+				case events <- Event{Type: EventSet, Key: line.Key, Value: line.Value, Page: c.config.currentPage, At: time.Now()}:
+				case <-ctx.Done():
+					return
+				}
+			}
+		}
+		for key := range snapshot {
+			if _, ok := seen[key]; !ok && watchMatches(key, opts, keys) {
+				select {
+				case events <- Event{Type: EventDelete, Key: key, Page: c.config.currentPage, At: time.Now()}:
+				case <-ctx.Done():
+					return
+				}
+			}
+		}
+		snapshot = seen
+	}
+}
@@ -0,0 +1,176 @@
+package creditdb
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"testing"
+	"time"
+)
+
+func recvEvent(t *testing.T, events <-chan Event) Event {
+	t.Helper()
+	select {
+	case ev, ok := <-events:
+		if !ok {
+			t.Fatal("events channel closed before expected event arrived")
+		}
+		return ev
+	case <-time.After(5 * time.Second):
+		t.Fatal("timed out waiting for an event")
+		return Event{}
+	}
+}
+
+func TestWatchSSEReconnectsAndResumesFromLastEventID(t *testing.T) {
+	var mu sync.Mutex
+	var lastIDs []string
+	connCount := 0
+
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		mu.Lock()
+		connCount++
+		n := connCount
+		lastIDs = append(lastIDs, r.Header.Get("Last-Event-ID"))
+		mu.Unlock()
+
+		w.Header().Set("Content-Type", "text/event-stream")
+		w.WriteHeader(http.StatusOK)
+		if n == 1 {
+			fmt.Fprint(w, "id: 1\nevent: set\ndata: {\"key\":\"a\",\"value\":\"1\"}\n\n")
+		} else {
+			fmt.Fprint(w, "id: 2\nevent: set\ndata: {\"key\":\"b\",\"value\":\"2\"}\n\n")
+		}
+		if flusher, ok := w.(http.Flusher); ok {
+			flusher.Flush()
+		}
+	}))
+	defer srv.Close()
+
+	c := testClient(srv.URL)
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	events, err := c.Watch(ctx)
+	if err != nil {
+		t.Fatalf("Watch returned error: %v", err)
+	}
+
+	first := recvEvent(t, events)
+	second := recvEvent(t, events)
+
+	if first.Key != "a" || second.Key != "b" {
+		t.Fatalf("expected events for keys a then b across a reconnect, got %+v then %+v", first, second)
+	}
+
+	mu.Lock()
+	defer mu.Unlock()
+	if len(lastIDs) < 2 {
+		t.Fatalf("expected at least 2 connections (the second being a reconnect), got %d", len(lastIDs))
+	}
+	if lastIDs[1] != "1" {
+		t.Fatalf("expected the reconnect to send Last-Event-ID: 1, got %q", lastIDs[1])
+	}
+}
+
+func TestWatchFallsBackToPollingWhenSSEUnsupported(t *testing.T) {
+	orig := watchPollInterval
+	watchPollInterval = 20 * time.Millisecond
+	defer func() { watchPollInterval = orig }()
+
+	var mu sync.Mutex
+	lines := []Line{{Key: "a", Value: "1"}}
+	getallCalls := 0
+	primed := make(chan struct{})
+
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/watch":
+			w.WriteHeader(http.StatusNotFound)
+		case "/getall":
+			mu.Lock()
+			resp := Page{Status: "OK", Result: append([]Line(nil), lines...)}
+			getallCalls++
+			if getallCalls == 1 {
+				close(primed)
+			}
+			mu.Unlock()
+			w.Header().Set("Content-Type", "application/json")
+			json.NewEncoder(w).Encode(resp)
+		default:
+			t.Fatalf("unexpected path %q", r.URL.Path)
+		}
+	}))
+	defer srv.Close()
+
+	c := testClient(srv.URL)
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	events, err := c.Watch(ctx)
+	if err != nil {
+		t.Fatalf("Watch returned error: %v", err)
+	}
+
+	// Wait for watchPoll's priming GetAllLines call (which seeds the
+	// snapshot with the pre-existing key "a") before adding "b", so the
+	// first diffed tick sees exactly one new key.
+	select {
+	case <-primed:
+	case <-time.After(5 * time.Second):
+		t.Fatal("timed out waiting for the polling fallback to prime its snapshot")
+	}
+
+	mu.Lock()
+	lines = append(lines, Line{Key: "b", Value: "2"})
+	mu.Unlock()
+
+	// Without IncludeInitial, the pre-existing key "a" must not be
+	// reported; only the key added after the subscription started.
+	first := recvEvent(t, events)
+	if first.Type != EventSet || first.Key != "b" {
+		t.Fatalf("expected EventSet for newly added key b only, got %+v", first)
+	}
+}
+
+func TestWatchIncludeInitialDoesNotDoubleFireWithPollingFallback(t *testing.T) {
+	orig := watchPollInterval
+	watchPollInterval = 20 * time.Millisecond
+	defer func() { watchPollInterval = orig }()
+
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/watch":
+			w.WriteHeader(http.StatusNotFound)
+		case "/getall":
+			w.Header().Set("Content-Type", "application/json")
+			json.NewEncoder(w).Encode(Page{Status: "OK", Result: []Line{{Key: "a", Value: "1"}}})
+		default:
+			t.Fatalf("unexpected path %q", r.URL.Path)
+		}
+	}))
+	defer srv.Close()
+
+	c := testClient(srv.URL)
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	events, err := c.WatchWithOptions(ctx, WatchOptions{IncludeInitial: true})
+	if err != nil {
+		t.Fatalf("WatchWithOptions returned error: %v", err)
+	}
+
+	first := recvEvent(t, events)
+	if first.Type != EventSet || first.Key != "a" {
+		t.Fatalf("expected the single initial EventSet for key a, got %+v", first)
+	}
+
+	select {
+	case ev := <-events:
+		t.Fatalf("expected no second event for key a once the polling fallback takes over, got %+v", ev)
+	case <-time.After(100 * time.Millisecond):
+	}
+}